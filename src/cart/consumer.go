@@ -0,0 +1,54 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+
+    "cart/internal/events"
+
+    "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// runConsumer starts the cart service in consumer mode: it subscribes to the
+// cart domain event topics and logs what it receives. Downstream services
+// like checkout run the same kind of Router-based consumer to join the
+// trace started by the producer in addToCart/emptyCart.
+func runConsumer() {
+    shutdown, _ := initOpenTelemetry()
+    defer shutdown()
+
+    broker := getEnv("PUBLISHER", "noop")
+    brokerAddr := getEnv("EVENTS_BROKER_ADDR", "localhost:9092")
+    consumerGroup := getEnv("EVENTS_CONSUMER_GROUP", "cart-consumer")
+
+    subscriber, err := events.NewSubscriber(broker, brokerAddr, consumerGroup)
+    if err != nil {
+        log.Fatalf("failed to create event subscriber: %v", err)
+    }
+
+    router, err := message.NewRouter(message.RouterConfig{}, events.NoopLogger{})
+    if err != nil {
+        log.Fatalf("failed to create event router: %v", err)
+    }
+    router.AddMiddleware(events.ConsumerMiddleware(tracer))
+
+    logHandler := func(topic string) message.NoPublishHandlerFunc {
+        return func(msg *message.Message) error {
+            var payload json.RawMessage
+            if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+                return err
+            }
+            log.Printf("received %s event: %s", topic, string(payload))
+            return nil
+        }
+    }
+
+    router.AddNoPublisherHandler("log_"+events.TopicItemAdded, events.TopicItemAdded, subscriber, logHandler(events.TopicItemAdded))
+    router.AddNoPublisherHandler("log_"+events.TopicEmptied, events.TopicEmptied, subscriber, logHandler(events.TopicEmptied))
+
+    log.Printf("Starting Cart Service in consumer mode (broker=%s)", broker)
+    if err := router.Run(context.Background()); err != nil {
+        log.Fatalf("event router stopped: %v", err)
+    }
+}
@@ -0,0 +1,272 @@
+// Package catalog is a resilient HTTP client for the product catalog
+// service: it retries transient failures with exponential backoff and trips
+// a circuit breaker per upstream host so a degraded catalog doesn't take the
+// whole cart service down with it.
+package catalog
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strconv"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/cenkalti/backoff/v4"
+    "github.com/sony/gobreaker"
+    "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// Product mirrors the product catalog service's response shape.
+type Product struct {
+    ID          string `json:"id"`
+    Name        string `json:"name"`
+    Description string `json:"description"`
+    Picture     string `json:"picture"`
+    PriceUSD    struct {
+        CurrencyCode string `json:"currency_code"`
+        Units        int64  `json:"units"`
+        Nanos        int32  `json:"nanos"`
+    } `json:"price_usd"`
+    Categories []string `json:"categories"`
+}
+
+// ErrorHook, when set, is called alongside span.RecordError for every error
+// GetProduct returns, so callers can mirror errors into a side channel such
+// as Sentry without this package depending on it directly.
+var ErrorHook func(ctx context.Context, err error)
+
+// Client is a resilient, OTel-instrumented client for the product catalog
+// service.
+type Client struct {
+    baseURL    string
+    httpClient *http.Client
+    tracer     trace.Tracer
+    maxRetries int
+
+    breakersMu sync.Mutex
+    breakers   map[string]*breakerEntry
+}
+
+// breakerEntry pairs a per-host circuit breaker with the span of the call
+// currently passing through it, so the breaker's OnStateChange hook can
+// record every transition as a span event instead of relying on the caller
+// to diff State() before and after Execute (which misses timer-driven
+// transitions, e.g. open->half-open, observed by whichever call happens to
+// go through next).
+type breakerEntry struct {
+    cb *gobreaker.CircuitBreaker
+
+    currentSpan atomic.Value // holds *spanHolder
+}
+
+// spanHolder lets breakerEntry.currentSpan store a trace.Span in an
+// atomic.Value: atomic.Value panics if successive Store calls don't use the
+// same concrete type, which a bare trace.Span (an interface) can't
+// guarantee across different span implementations.
+type spanHolder struct {
+    span trace.Span
+}
+
+// NewClient builds a Client pointed at baseURL (e.g.
+// http://productcatalog:7000) with the given per-request timeout.
+func NewClient(baseURL string, timeout time.Duration, tracer trace.Tracer) *Client {
+    return &Client{
+        baseURL: baseURL,
+        httpClient: &http.Client{
+            Transport: otelhttp.NewTransport(http.DefaultTransport),
+            Timeout:   timeout,
+        },
+        tracer:     tracer,
+        maxRetries: 3,
+        breakers:   make(map[string]*breakerEntry),
+    }
+}
+
+// breakerFor returns the circuit breaker entry for host, creating it on
+// first use.
+func (c *Client) breakerFor(host string) *breakerEntry {
+    c.breakersMu.Lock()
+    defer c.breakersMu.Unlock()
+
+    if entry, ok := c.breakers[host]; ok {
+        return entry
+    }
+
+    entry := &breakerEntry{}
+    entry.cb = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+        Name:    host,
+        Timeout: 30 * time.Second,
+        ReadyToTrip: func(counts gobreaker.Counts) bool {
+            return counts.ConsecutiveFailures >= 5
+        },
+        OnStateChange: func(name string, from, to gobreaker.State) {
+            holder, ok := entry.currentSpan.Load().(*spanHolder)
+            if !ok || holder.span == nil {
+                return
+            }
+            holder.span.AddEvent("circuit_breaker.state_change", trace.WithAttributes(
+                attribute.String("circuit_breaker.from_state", from.String()),
+                attribute.String("circuit_breaker.to_state", to.String()),
+            ))
+        },
+    })
+    c.breakers[host] = entry
+    return entry
+}
+
+// GetProduct fetches productID from the catalog, retrying transient errors
+// with backoff and going through a per-host circuit breaker.
+func (c *Client) GetProduct(ctx context.Context, productID string) (*Product, error) {
+    ctx, span := c.tracer.Start(ctx, "get_product_from_catalog")
+    defer span.End()
+    span.SetAttributes(attribute.String("product.id", productID))
+
+    requestURL := fmt.Sprintf("%s/products/%s", c.baseURL, productID)
+    host := hostOf(requestURL)
+
+    entry := c.breakerFor(host)
+    entry.currentSpan.Store(&spanHolder{span: span})
+
+    result, err := entry.cb.Execute(func() (interface{}, error) {
+        return c.getWithRetries(ctx, requestURL)
+    })
+
+    if err != nil {
+        span.RecordError(err)
+        if ErrorHook != nil {
+            ErrorHook(ctx, err)
+        }
+        return nil, err
+    }
+
+    product := result.(*Product)
+    span.SetAttributes(attribute.String("product.name", product.Name))
+    return product, nil
+}
+
+// statusError carries the upstream status code and an optional Retry-After
+// hint so getWithRetries can decide whether and how long to wait.
+type statusError struct {
+    statusCode int
+    retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+    if e.statusCode == http.StatusNotFound {
+        return "product not found"
+    }
+    return fmt.Sprintf("catalog returned status %d", e.statusCode)
+}
+
+func (e *statusError) retryable() bool {
+    return e.statusCode == http.StatusTooManyRequests || e.statusCode == http.StatusServiceUnavailable
+}
+
+func (c *Client) getWithRetries(ctx context.Context, requestURL string) (*Product, error) {
+    backOff := backoff.NewExponentialBackOff()
+
+    var lastErr error
+    for attempt := 0; attempt <= c.maxRetries; attempt++ {
+        product, err := c.doAttempt(ctx, requestURL, attempt)
+        if err == nil {
+            return product, nil
+        }
+        lastErr = err
+
+        statusErr, isStatusErr := err.(*statusError)
+        retryable := true
+        switch {
+        case isStatusErr:
+            retryable = statusErr.retryable()
+        case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+            retryable = false
+        }
+        if !retryable || attempt == c.maxRetries {
+            break
+        }
+
+        wait := backOff.NextBackOff()
+        if isStatusErr && statusErr.retryAfter > 0 {
+            wait = statusErr.retryAfter
+        }
+        select {
+        case <-time.After(wait):
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        }
+    }
+    return nil, lastErr
+}
+
+// doAttempt wraps a single doRequest call in its own child span carrying
+// http.resend_count, so each retry shows up as its own span rather than
+// overwriting an attribute on the parent.
+func (c *Client) doAttempt(ctx context.Context, requestURL string, attempt int) (*Product, error) {
+    ctx, span := c.tracer.Start(ctx, "get_product_from_catalog/attempt",
+        trace.WithAttributes(attribute.Int("http.resend_count", attempt)),
+    )
+    defer span.End()
+
+    product, err := c.doRequest(ctx, requestURL)
+    if err != nil {
+        span.RecordError(err)
+    }
+    return product, err
+}
+
+func (c *Client) doRequest(ctx context.Context, requestURL string) (*Product, error) {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get product: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, &statusError{statusCode: resp.StatusCode, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+    }
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %w", err)
+    }
+
+    var product Product
+    if err := json.Unmarshal(body, &product); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal product: %w", err)
+    }
+    return &product, nil
+}
+
+func parseRetryAfter(header string) time.Duration {
+    if header == "" {
+        return 0
+    }
+    if seconds, err := strconv.Atoi(header); err == nil {
+        return time.Duration(seconds) * time.Second
+    }
+    if when, err := http.ParseTime(header); err == nil {
+        return time.Until(when)
+    }
+    return 0
+}
+
+func hostOf(rawURL string) string {
+    u, err := url.Parse(rawURL)
+    if err != nil {
+        return rawURL
+    }
+    return u.Host
+}
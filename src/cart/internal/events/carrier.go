@@ -0,0 +1,26 @@
+package events
+
+import "github.com/ThreeDotsLabs/watermill/message"
+
+// MessageCarrier adapts a Watermill message's metadata to
+// propagation.TextMapCarrier so the OTel propagator can inject and extract
+// W3C tracecontext on it.
+type MessageCarrier struct {
+    Metadata message.Metadata
+}
+
+func (c MessageCarrier) Get(key string) string {
+    return c.Metadata.Get(key)
+}
+
+func (c MessageCarrier) Set(key, value string) {
+    c.Metadata.Set(key, value)
+}
+
+func (c MessageCarrier) Keys() []string {
+    keys := make([]string, 0, len(c.Metadata))
+    for k := range c.Metadata {
+        keys = append(keys, k)
+    }
+    return keys
+}
@@ -0,0 +1,46 @@
+// Package events publishes cart domain events to a message broker with
+// OpenTelemetry context propagation, so downstream services (e.g. checkout)
+// joining the same topics stay in the same trace.
+package events
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    "github.com/ThreeDotsLabs/watermill"
+    "github.com/ThreeDotsLabs/watermill/message"
+)
+
+const (
+    // TopicItemAdded is published whenever an item is added to a cart.
+    TopicItemAdded = "cart.item_added"
+    // TopicEmptied is published whenever a cart is emptied.
+    TopicEmptied = "cart.emptied"
+)
+
+// ItemAddedEvent is the payload of a TopicItemAdded message.
+type ItemAddedEvent struct {
+    UserID    string `json:"user_id"`
+    ProductID string `json:"product_id"`
+    Quantity  int    `json:"quantity"`
+}
+
+// EmptiedEvent is the payload of a TopicEmptied message.
+type EmptiedEvent struct {
+    UserID       string `json:"user_id"`
+    ItemsRemoved int    `json:"items_removed"`
+}
+
+// Publish marshals payload as JSON and publishes it to topic via pub. ctx
+// carries the trace the producer span will be a child of.
+func Publish(ctx context.Context, pub message.Publisher, topic string, payload any) error {
+    body, err := json.Marshal(payload)
+    if err != nil {
+        return fmt.Errorf("failed to marshal %s event: %w", topic, err)
+    }
+
+    msg := message.NewMessage(watermill.NewUUID(), body)
+    msg.SetContext(ctx)
+    return pub.Publish(topic, msg)
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+    "github.com/ThreeDotsLabs/watermill/message"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// tracingPublisher decorates a message.Publisher so that every published
+// message gets a producer span and carries the current trace context in its
+// metadata, analogous to the msgotel instrumentation pattern.
+type tracingPublisher struct {
+    message.Publisher
+    tracer trace.Tracer
+}
+
+// NewTracingPublisher wraps pub so every Publish call produces a
+// "<topic> publish" span and injects W3C tracecontext into each message's
+// metadata.
+func NewTracingPublisher(pub message.Publisher, tracer trace.Tracer) message.Publisher {
+    return &tracingPublisher{Publisher: pub, tracer: tracer}
+}
+
+func (p *tracingPublisher) Publish(topic string, messages ...*message.Message) error {
+    for _, msg := range messages {
+        ctx, span := p.tracer.Start(msg.Context(), topic+" publish", trace.WithSpanKind(trace.SpanKindProducer))
+        span.SetAttributes(
+            attribute.String("messaging.system", "watermill"),
+            attribute.String("messaging.destination", topic),
+            attribute.String("messaging.message_id", msg.UUID),
+        )
+
+        otel.GetTextMapPropagator().Inject(ctx, MessageCarrier{msg.Metadata})
+        msg.SetContext(ctx)
+
+        if err := p.Publisher.Publish(topic, msg); err != nil {
+            span.RecordError(err)
+            span.End()
+            return err
+        }
+        span.End()
+    }
+    return nil
+}
+
+// ConsumerMiddleware returns a Watermill router middleware that extracts the
+// W3C tracecontext injected by NewTracingPublisher and starts a "<topic>
+// process" consumer span as its child, so the consumer joins the same trace
+// the producer started in addToCart/emptyCart rather than starting a new one.
+func ConsumerMiddleware(tracer trace.Tracer) message.HandlerMiddleware {
+    return func(h message.HandlerFunc) message.HandlerFunc {
+        return func(msg *message.Message) ([]*message.Message, error) {
+            producerCtx := otel.GetTextMapPropagator().Extract(msg.Context(), MessageCarrier{msg.Metadata})
+
+            topic := message.SubscribeTopicFromCtx(msg.Context())
+            ctx, span := tracer.Start(producerCtx, topic+" process",
+                trace.WithSpanKind(trace.SpanKindConsumer),
+            )
+            defer span.End()
+
+            span.SetAttributes(
+                attribute.String("messaging.system", "watermill"),
+                attribute.String("messaging.destination", topic),
+                attribute.String("messaging.message_id", msg.UUID),
+            )
+
+            msg.SetContext(ctx)
+            out, err := h(msg)
+            if err != nil {
+                span.RecordError(err)
+            }
+            return out, err
+        }
+    }
+}
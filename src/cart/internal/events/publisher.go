@@ -0,0 +1,62 @@
+package events
+
+import (
+    "fmt"
+
+    "github.com/ThreeDotsLabs/watermill"
+    "github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
+    "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+    "github.com/ThreeDotsLabs/watermill/message"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// NoopLogger is a watermill.LoggerAdapter that discards everything; the
+// cart service relies on OTel spans instead of broker client logs.
+type NoopLogger struct{}
+
+func (NoopLogger) Error(msg string, err error, fields watermill.LogFields) {}
+func (NoopLogger) Info(msg string, fields watermill.LogFields)            {}
+func (NoopLogger) Debug(msg string, fields watermill.LogFields)           {}
+func (NoopLogger) Trace(msg string, fields watermill.LogFields)           {}
+func (l NoopLogger) With(fields watermill.LogFields) watermill.LoggerAdapter {
+    return l
+}
+
+// NewPublisher builds the message.Publisher selected by the PUBLISHER env
+// var (noop, kafka, or nats), wrapped with OTel producer instrumentation.
+func NewPublisher(broker string, brokerAddr string, tracer trace.Tracer) (message.Publisher, error) {
+    var pub message.Publisher
+    switch broker {
+    case "", "noop":
+        pub = &noopPublisher{}
+    case "kafka":
+        p, err := kafka.NewPublisher(kafka.PublisherConfig{
+            Brokers:   []string{brokerAddr},
+            Marshaler: kafka.DefaultMarshaler{},
+        }, NoopLogger{})
+        if err != nil {
+            return nil, fmt.Errorf("failed to create kafka publisher: %w", err)
+        }
+        pub = p
+    case "nats":
+        p, err := nats.NewPublisher(nats.PublisherConfig{
+            URL:       brokerAddr,
+            Marshaler: &nats.GobMarshaler{},
+        }, NoopLogger{})
+        if err != nil {
+            return nil, fmt.Errorf("failed to create nats publisher: %w", err)
+        }
+        pub = p
+    default:
+        return nil, fmt.Errorf("unknown PUBLISHER %q (want noop, kafka, or nats)", broker)
+    }
+
+    return NewTracingPublisher(pub, tracer), nil
+}
+
+// noopPublisher discards every message. It is the default so the cart
+// service runs without a broker dependency until one is configured.
+type noopPublisher struct{}
+
+func (*noopPublisher) Publish(topic string, messages ...*message.Message) error { return nil }
+func (*noopPublisher) Close() error                                            { return nil }
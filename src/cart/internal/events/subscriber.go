@@ -0,0 +1,31 @@
+package events
+
+import (
+    "fmt"
+
+    "github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
+    "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+    "github.com/ThreeDotsLabs/watermill/message"
+)
+
+// NewSubscriber builds the message.Subscriber matching the broker selected
+// by the PUBLISHER env var. consumerGroup is the Kafka consumer group, and
+// the queue group prefix for NATS.
+func NewSubscriber(broker, brokerAddr, consumerGroup string) (message.Subscriber, error) {
+    switch broker {
+    case "kafka":
+        return kafka.NewSubscriber(kafka.SubscriberConfig{
+            Brokers:       []string{brokerAddr},
+            Unmarshaler:   kafka.DefaultMarshaler{},
+            ConsumerGroup: consumerGroup,
+        }, NoopLogger{})
+    case "nats":
+        return nats.NewSubscriber(nats.SubscriberConfig{
+            URL:              brokerAddr,
+            Unmarshaler:      &nats.GobMarshaler{},
+            QueueGroupPrefix: consumerGroup,
+        }, NoopLogger{})
+    default:
+        return nil, fmt.Errorf("unknown PUBLISHER %q for consumer mode (want kafka or nats)", broker)
+    }
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+    "context"
+    "sync"
+)
+
+// MemoryStore is an in-process CartStore. It does not survive pod restarts
+// and exists for local development and as the zero-dependency default.
+type MemoryStore struct {
+    mutex sync.RWMutex
+    carts map[string]*Cart
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{carts: make(map[string]*Cart)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, userID string) (*Cart, error) {
+    s.mutex.RLock()
+    defer s.mutex.RUnlock()
+
+    cart, exists := s.carts[userID]
+    if !exists {
+        return &Cart{UserID: userID, Items: []CartItem{}}, nil
+    }
+
+    // Return a copy so callers can't mutate our internal state.
+    itemsCopy := make([]CartItem, len(cart.Items))
+    copy(itemsCopy, cart.Items)
+    return &Cart{UserID: cart.UserID, Items: itemsCopy}, nil
+}
+
+func (s *MemoryStore) AddItem(_ context.Context, userID string, item CartItem) error {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+
+    cart, exists := s.carts[userID]
+    if !exists {
+        cart = &Cart{UserID: userID, Items: []CartItem{}}
+        s.carts[userID] = cart
+    }
+
+    for i, existingItem := range cart.Items {
+        if existingItem.ProductID == item.ProductID {
+            cart.Items[i].Quantity += item.Quantity
+            return nil
+        }
+    }
+    cart.Items = append(cart.Items, item)
+    return nil
+}
+
+func (s *MemoryStore) Empty(_ context.Context, userID string) (int, error) {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+
+    cart, exists := s.carts[userID]
+    if !exists {
+        return 0, nil
+    }
+
+    itemsRemoved := 0
+    for _, item := range cart.Items {
+        itemsRemoved += item.Quantity
+    }
+    cart.Items = []CartItem{}
+    return itemsRemoved, nil
+}
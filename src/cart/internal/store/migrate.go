@@ -0,0 +1,69 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "embed"
+    "fmt"
+    "sort"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// runMigrations applies any embedded .sql files that have not yet been
+// recorded in the schema_migrations table, in filename order. It is safe to
+// call on every startup.
+func runMigrations(ctx context.Context, db *sql.DB) error {
+    if _, err := db.ExecContext(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            name       TEXT PRIMARY KEY,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+        )
+    `); err != nil {
+        return fmt.Errorf("failed to create schema_migrations table: %w", err)
+    }
+
+    entries, err := migrationFiles.ReadDir("migrations")
+    if err != nil {
+        return fmt.Errorf("failed to read embedded migrations: %w", err)
+    }
+    sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+    for _, entry := range entries {
+        name := entry.Name()
+
+        var alreadyApplied bool
+        if err := db.QueryRowContext(ctx,
+            `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE name = $1)`, name,
+        ).Scan(&alreadyApplied); err != nil {
+            return fmt.Errorf("failed to check migration status for %s: %w", name, err)
+        }
+        if alreadyApplied {
+            continue
+        }
+
+        sqlBytes, err := migrationFiles.ReadFile("migrations/" + name)
+        if err != nil {
+            return fmt.Errorf("failed to read migration %s: %w", name, err)
+        }
+
+        tx, err := db.BeginTx(ctx, nil)
+        if err != nil {
+            return fmt.Errorf("failed to begin migration transaction for %s: %w", name, err)
+        }
+        if _, err := tx.ExecContext(ctx, string(sqlBytes)); err != nil {
+            tx.Rollback()
+            return fmt.Errorf("failed to apply migration %s: %w", name, err)
+        }
+        if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (name) VALUES ($1)`, name); err != nil {
+            tx.Rollback()
+            return fmt.Errorf("failed to record migration %s: %w", name, err)
+        }
+        if err := tx.Commit(); err != nil {
+            return fmt.Errorf("failed to commit migration %s: %w", name, err)
+        }
+    }
+
+    return nil
+}
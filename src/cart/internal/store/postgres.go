@@ -0,0 +1,141 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "os"
+    "strconv"
+    "time"
+
+    "github.com/uptrace/bun"
+    "github.com/uptrace/bun/dialect/pgdialect"
+    "github.com/uptrace/bun/driver/pgdriver"
+    "github.com/uptrace/bun/extra/bunotel"
+)
+
+// cartModel is the bun mapping for the carts table.
+type cartModel struct {
+    bun.BaseModel `bun:"table:carts"`
+
+    UserID string `bun:"user_id,pk"`
+}
+
+// cartItemModel is the bun mapping for the cart_items table.
+type cartItemModel struct {
+    bun.BaseModel `bun:"table:cart_items"`
+
+    UserID    string `bun:"user_id,pk"`
+    ProductID string `bun:"product_id,pk"`
+    Quantity  int    `bun:"quantity"`
+}
+
+// PostgresStore is a CartStore backed by Postgres via bun. Every query it
+// issues is wrapped in a child span under the caller's context by the
+// bunotel query hook.
+type PostgresStore struct {
+    db *bun.DB
+}
+
+// NewPostgresStore opens a connection pool to dsn, registers the OTel query
+// hook, and applies any pending migrations.
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+    sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+    sqldb.SetMaxOpenConns(getEnvInt("CART_STORE_MAX_OPEN_CONNS", 10))
+    sqldb.SetMaxIdleConns(getEnvInt("CART_STORE_MAX_IDLE_CONNS", 5))
+    sqldb.SetConnMaxLifetime(5 * time.Minute)
+
+    if err := sqldb.PingContext(ctx); err != nil {
+        return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+    }
+
+    if err := runMigrations(ctx, sqldb); err != nil {
+        return nil, fmt.Errorf("failed to run migrations: %w", err)
+    }
+
+    db := bun.NewDB(sqldb, pgdialect.New())
+    db.AddQueryHook(bunotel.NewQueryHook(
+        bunotel.WithDBName("carts"),
+    ))
+
+    return &PostgresStore{db: db}, nil
+}
+
+// Close releases the underlying connection pool.
+func (s *PostgresStore) Close() error {
+    return s.db.Close()
+}
+
+func (s *PostgresStore) Get(ctx context.Context, userID string) (*Cart, error) {
+    var items []cartItemModel
+    err := s.db.NewSelect().
+        Model(&items).
+        Where("user_id = ?", userID).
+        Scan(ctx)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get cart for user %s: %w", userID, err)
+    }
+
+    cart := &Cart{UserID: userID, Items: make([]CartItem, 0, len(items))}
+    for _, item := range items {
+        cart.Items = append(cart.Items, CartItem{ProductID: item.ProductID, Quantity: item.Quantity})
+    }
+    return cart, nil
+}
+
+func (s *PostgresStore) AddItem(ctx context.Context, userID string, item CartItem) error {
+    return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+        if _, err := tx.NewInsert().
+            Model(&cartModel{UserID: userID}).
+            On("CONFLICT (user_id) DO NOTHING").
+            Exec(ctx); err != nil {
+            return fmt.Errorf("failed to ensure cart for user %s: %w", userID, err)
+        }
+
+        row := &cartItemModel{UserID: userID, ProductID: item.ProductID, Quantity: item.Quantity}
+        if _, err := tx.NewInsert().
+            Model(row).
+            On("CONFLICT (user_id, product_id) DO UPDATE").
+            Set("quantity = ?TableAlias.quantity + EXCLUDED.quantity").
+            Exec(ctx); err != nil {
+            return fmt.Errorf("failed to add item %s for user %s: %w", item.ProductID, userID, err)
+        }
+        return nil
+    })
+}
+
+func (s *PostgresStore) Empty(ctx context.Context, userID string) (int, error) {
+    var itemsRemoved int
+    err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+        var total sql.NullInt64
+        if err := tx.NewSelect().
+            Model((*cartItemModel)(nil)).
+            ColumnExpr("COALESCE(SUM(quantity), 0)").
+            Where("user_id = ?", userID).
+            Scan(ctx, &total); err != nil {
+            return fmt.Errorf("failed to total items for user %s: %w", userID, err)
+        }
+        itemsRemoved = int(total.Int64)
+
+        if _, err := tx.NewDelete().
+            Model((*cartItemModel)(nil)).
+            Where("user_id = ?", userID).
+            Exec(ctx); err != nil {
+            return fmt.Errorf("failed to empty cart for user %s: %w", userID, err)
+        }
+        return nil
+    })
+    return itemsRemoved, err
+}
+
+func getEnvInt(key string, defaultValue int) int {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return defaultValue
+    }
+    value, err := strconv.Atoi(raw)
+    if err != nil {
+        return defaultValue
+    }
+    return value
+}
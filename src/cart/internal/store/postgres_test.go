@@ -0,0 +1,141 @@
+//go:build integration
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// newTestStore spins up a throwaway Postgres container, points a
+// PostgresStore at it (running the embedded migrations), and registers
+// cleanup for both.
+func newTestStore(t *testing.T) *PostgresStore {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tcpostgres.Run(ctx, "postgres:16-alpine",
+		tcpostgres.WithDatabase("carts"),
+		tcpostgres.WithUsername("carts"),
+		tcpostgres.WithPassword("carts"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").WithOccurrence(2).WithStartupTimeout(30*time.Second),
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate postgres container: %v", err)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("failed to get postgres connection string: %v", err)
+	}
+
+	store, err := NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to create postgres store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestPostgresStore_GetEmptyCart(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	cart, err := store.Get(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if cart.UserID != "user-1" {
+		t.Errorf("UserID = %q, want %q", cart.UserID, "user-1")
+	}
+	if len(cart.Items) != 0 {
+		t.Errorf("Items = %v, want empty", cart.Items)
+	}
+}
+
+func TestPostgresStore_AddItemMergesQuantityOnConflict(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.AddItem(ctx, "user-1", CartItem{ProductID: "OLJCESPC7Z", Quantity: 2}); err != nil {
+		t.Fatalf("first AddItem returned error: %v", err)
+	}
+	if err := store.AddItem(ctx, "user-1", CartItem{ProductID: "OLJCESPC7Z", Quantity: 3}); err != nil {
+		t.Fatalf("second AddItem returned error: %v", err)
+	}
+
+	cart, err := store.Get(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if len(cart.Items) != 1 {
+		t.Fatalf("Items = %v, want a single merged line item", cart.Items)
+	}
+	if cart.Items[0].Quantity != 5 {
+		t.Errorf("Quantity = %d, want 5 (2 + 3 merged on conflict)", cart.Items[0].Quantity)
+	}
+}
+
+func TestPostgresStore_EmptyRemovesAllItemsAndReportsCount(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.AddItem(ctx, "user-1", CartItem{ProductID: "OLJCESPC7Z", Quantity: 2}); err != nil {
+		t.Fatalf("AddItem returned error: %v", err)
+	}
+	if err := store.AddItem(ctx, "user-1", CartItem{ProductID: "66VCHSJNUP", Quantity: 1}); err != nil {
+		t.Fatalf("AddItem returned error: %v", err)
+	}
+
+	itemsRemoved, err := store.Empty(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Empty returned error: %v", err)
+	}
+	if itemsRemoved != 3 {
+		t.Errorf("itemsRemoved = %d, want 3", itemsRemoved)
+	}
+
+	cart, err := store.Get(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if len(cart.Items) != 0 {
+		t.Errorf("Items after Empty = %v, want empty", cart.Items)
+	}
+}
+
+func TestPostgresStore_DeletingCartCascadesToItems(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.AddItem(ctx, "user-1", CartItem{ProductID: "OLJCESPC7Z", Quantity: 2}); err != nil {
+		t.Fatalf("AddItem returned error: %v", err)
+	}
+
+	if _, err := store.db.NewDelete().Model((*cartModel)(nil)).Where("user_id = ?", "user-1").Exec(ctx); err != nil {
+		t.Fatalf("failed to delete cart row: %v", err)
+	}
+
+	var remaining int
+	count, err := store.db.NewSelect().Model((*cartItemModel)(nil)).Where("user_id = ?", "user-1").Count(ctx)
+	if err != nil {
+		t.Fatalf("failed to count cart_items: %v", err)
+	}
+	remaining = count
+	if remaining != 0 {
+		t.Errorf("cart_items remaining after cart delete = %d, want 0 (ON DELETE CASCADE)", remaining)
+	}
+}
@@ -0,0 +1,40 @@
+// Package store defines the cart persistence layer and its implementations.
+package store
+
+import (
+    "context"
+    "errors"
+)
+
+// ErrCartNotFound reports that a cart has never been created for the given
+// user. MemoryStore and PostgresStore do not return it: their Get returns an
+// empty cart instead.
+var ErrCartNotFound = errors.New("cart not found")
+
+// Cart represents a shopping cart for a single user.
+type Cart struct {
+    UserID string     `json:"user_id"`
+    Items  []CartItem `json:"items"`
+}
+
+// CartItem represents an item in a cart.
+type CartItem struct {
+    ProductID string `json:"product_id"`
+    Quantity  int    `json:"quantity"`
+}
+
+// CartStore persists carts and their items. Implementations must be safe for
+// concurrent use.
+type CartStore interface {
+    // Get returns the cart for userID, creating an empty one in memory (but
+    // not persisting it) if none exists yet.
+    Get(ctx context.Context, userID string) (*Cart, error)
+
+    // AddItem adds quantity units of productID to the user's cart, merging
+    // with any existing line item for the same product.
+    AddItem(ctx context.Context, userID string, item CartItem) error
+
+    // Empty removes all items from the user's cart and returns how many
+    // units were removed, for metrics purposes.
+    Empty(ctx context.Context, userID string) (itemsRemoved int, err error)
+}
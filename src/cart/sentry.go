@@ -0,0 +1,93 @@
+package main
+
+import (
+    "context"
+    "log"
+    "strconv"
+    "time"
+
+    "github.com/getsentry/sentry-go"
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// sentryEnabled is set once in main() after a successful sentry.Init, and
+// read by recordError and by main() to decide whether to install the Sentry
+// Gin middleware and the catalog error hook.
+var sentryEnabled bool
+
+// initSentry configures the Sentry SDK from SENTRY_DSN. It returns false
+// (and does nothing else) when SENTRY_DSN is unset, so Sentry stays fully
+// optional.
+func initSentry() bool {
+    dsn := getEnv("SENTRY_DSN", "")
+    if dsn == "" {
+        return false
+    }
+
+    if err := sentry.Init(sentry.ClientOptions{
+        Dsn:              dsn,
+        AttachStacktrace: true,
+    }); err != nil {
+        log.Printf("failed to initialize sentry: %v", err)
+        return false
+    }
+    return true
+}
+
+// sentryMiddleware recovers panics, reports them to Sentry with the current
+// trace/span IDs attached as tags, and re-panics (or not) per SENTRY_REPANIC
+// after waiting up to SENTRY_FLUSH_TIMEOUT for delivery.
+func sentryMiddleware() gin.HandlerFunc {
+    repanic := getEnv("SENTRY_REPANIC", "true") == "true"
+    flushTimeout := 2 * time.Second
+    if raw := getEnv("SENTRY_FLUSH_TIMEOUT", ""); raw != "" {
+        if seconds, err := strconv.Atoi(raw); err == nil {
+            flushTimeout = time.Duration(seconds) * time.Second
+        }
+    }
+
+    return func(c *gin.Context) {
+        defer func() {
+            if recovered := recover(); recovered != nil {
+                hub := sentry.GetHubFromContext(c.Request.Context())
+                if hub == nil {
+                    hub = sentry.CurrentHub().Clone()
+                }
+                hub.Scope().SetTags(traceTags(c.Request.Context()))
+                hub.RecoverWithContext(c.Request.Context(), recovered)
+                hub.Flush(flushTimeout)
+
+                if repanic {
+                    panic(recovered)
+                }
+                c.AbortWithStatus(500)
+            }
+        }()
+        c.Next()
+    }
+}
+
+// recordError records err on span and, when Sentry is enabled, mirrors it to
+// Sentry tagged with the current trace/span IDs so both backends see it.
+func recordError(ctx context.Context, span trace.Span, err error) {
+    span.RecordError(err)
+    if !sentryEnabled {
+        return
+    }
+
+    hub := sentry.CurrentHub().Clone()
+    hub.Scope().SetTags(traceTags(ctx))
+    hub.CaptureException(err)
+}
+
+func traceTags(ctx context.Context) map[string]string {
+    spanCtx := trace.SpanContextFromContext(ctx)
+    if !spanCtx.IsValid() {
+        return nil
+    }
+    return map[string]string{
+        "trace_id": spanCtx.TraceID().String(),
+        "span_id":  spanCtx.SpanID().String(),
+    }
+}
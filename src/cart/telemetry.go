@@ -0,0 +1,98 @@
+package main
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/propagation"
+    semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// TelemetryConfig controls what tracingMiddleware records: which routes are
+// skipped entirely, and which request/response headers are copied onto the
+// span as attributes.
+type TelemetryConfig struct {
+    // IgnoredRoutes are paths for which no span is created at all, so
+    // things like k8s liveness probes don't pollute traces and metrics.
+    IgnoredRoutes []string
+    // TraceRequestHeaders are header names copied onto the span as
+    // http.request.header.<name> (lowercased).
+    TraceRequestHeaders []string
+    // TraceResponseHeaders are header names copied onto the span as
+    // http.response.header.<name> (lowercased).
+    TraceResponseHeaders []string
+}
+
+// defaultTelemetryConfig ignores /health and /metrics, the two routes hit by
+// k8s probes and Prometheus scrapes rather than real traffic.
+func defaultTelemetryConfig() TelemetryConfig {
+    return TelemetryConfig{
+        IgnoredRoutes: []string{"/health", "/metrics"},
+    }
+}
+
+func (cfg TelemetryConfig) isIgnored(path string) bool {
+    for _, ignored := range cfg.IgnoredRoutes {
+        if path == ignored {
+            return true
+        }
+    }
+    return false
+}
+
+// tracingMiddleware is a replacement for otelgin.Middleware that additionally
+// skips IgnoredRoutes, tags the span with the configured request/response
+// headers, and uses the matched Gin route template (e.g.
+// /cart/:user_id/items) as http.route, so the per-endpoint attributes
+// previously hand-typed in each handler can be derived from the span alone.
+func tracingMiddleware(service string, cfg TelemetryConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        if cfg.isIgnored(c.Request.URL.Path) {
+            c.Next()
+            return
+        }
+
+        route := c.FullPath()
+        spanName := route
+        if spanName == "" {
+            spanName = fmt.Sprintf("HTTP %s route not found", c.Request.Method)
+        }
+
+        parentCtx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+        ctx, span := tracer.Start(parentCtx, spanName,
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                semconv.HTTPMethod(c.Request.Method),
+                semconv.HTTPTarget(c.Request.URL.Path),
+            ),
+        )
+        if route != "" {
+            span.SetAttributes(semconv.HTTPRoute(route))
+        }
+        for _, name := range cfg.TraceRequestHeaders {
+            if value := c.Request.Header.Get(name); value != "" {
+                span.SetAttributes(attribute.String("http.request.header."+strings.ToLower(name), value))
+            }
+        }
+        c.Request = c.Request.WithContext(ctx)
+
+        c.Next()
+
+        status := c.Writer.Status()
+        span.SetAttributes(semconv.HTTPStatusCode(status))
+        if status >= 500 {
+            span.SetStatus(codes.Error, "")
+        }
+        for _, name := range cfg.TraceResponseHeaders {
+            if value := c.Writer.Header().Get(name); value != "" {
+                span.SetAttributes(attribute.String("http.response.header."+strings.ToLower(name), value))
+            }
+        }
+        span.End()
+    }
+}